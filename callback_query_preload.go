@@ -1,13 +1,110 @@
 package gorm
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
 )
 
+// defaultPreloadParallelism is the number of preload schemas run concurrently
+// when "gorm:preload_parallelism" hasn't been set on the DB/scope.
+const defaultPreloadParallelism = 1
+
+// PreloadHandlerFunc preloads field's association onto scope.Value, in the
+// same spirit as handleHasOnePreload/handleHasManyPreload/etc: conditions are
+// the caller-supplied Preload conditions, parent is the SQL expression for
+// the rows currently being preloaded, and the returned *SqlExpr is passed as
+// parent to the next nested preload level.
+type PreloadHandlerFunc func(scope *Scope, field *Field, conditions []interface{}, parent *SqlExpr) *SqlExpr
+
+// defaultPreloadHandlers are the built-in relationship kinds. They're used for
+// any kind that has no handler registered via RegisterPreloadHandler, so
+// registering one of these kinds replaces the built-in handler for it. The
+// built-in handlers aren't exported, so a handler registered from outside this
+// package can't call the built-in one it replaces.
+var defaultPreloadHandlers = map[string]PreloadHandlerFunc{
+	"has_one": func(scope *Scope, field *Field, conditions []interface{}, parent *SqlExpr) *SqlExpr {
+		return scope.handleHasOnePreload(field, conditions, parent)
+	},
+	"has_many": func(scope *Scope, field *Field, conditions []interface{}, parent *SqlExpr) *SqlExpr {
+		return scope.handleHasManyPreload(field, conditions, parent)
+	},
+	"belongs_to": func(scope *Scope, field *Field, conditions []interface{}, parent *SqlExpr) *SqlExpr {
+		return scope.handleBelongsToPreload(field, conditions, parent)
+	},
+	"many_to_many": func(scope *Scope, field *Field, conditions []interface{}, parent *SqlExpr) *SqlExpr {
+		return scope.handleManyToManyPreload(field, conditions, parent)
+	},
+}
+
+// RegisterPreloadHandler registers fn as the handler used to preload
+// relationships of the given kind (e.g. "has_one", or a custom kind such as
+// "polymorphic_any" or "closure_table"), overriding the built-in handler if
+// kind already has one. Like other per-DB settings it's carried by Set, so it
+// applies to the returned DB and DBs derived from it, not to db itself.
+func (db *DB) RegisterPreloadHandler(kind string, fn PreloadHandlerFunc) *DB {
+	existing := map[string]PreloadHandlerFunc{}
+	if v, ok := db.Get("gorm:preload_handlers"); ok {
+		if m, ok := v.(map[string]PreloadHandlerFunc); ok {
+			existing = m
+		}
+	}
+	return db.Set("gorm:preload_handlers", mergePreloadHandlers(existing, kind, fn))
+}
+
+// mergePreloadHandlers returns a copy of existing with kind mapped to fn,
+// leaving existing itself untouched.
+func mergePreloadHandlers(existing map[string]PreloadHandlerFunc, kind string, fn PreloadHandlerFunc) map[string]PreloadHandlerFunc {
+	handlers := make(map[string]PreloadHandlerFunc, len(existing)+1)
+	for k, v := range existing {
+		handlers[k] = v
+	}
+	handlers[kind] = fn
+	return handlers
+}
+
+// preloadHandler looks up the handler for kind, preferring one registered via
+// RegisterPreloadHandler over the built-in handlers.
+func (scope *Scope) preloadHandler(kind string) (PreloadHandlerFunc, bool) {
+	var registered map[string]PreloadHandlerFunc
+	if v, ok := scope.Get("gorm:preload_handlers"); ok {
+		if m, ok := v.(map[string]PreloadHandlerFunc); ok {
+			registered = m
+		}
+	}
+	return resolvePreloadHandler(registered, kind)
+}
+
+// resolvePreloadHandler looks kind up in registered first, falling back to
+// defaultPreloadHandlers -- the same override-then-fallback precedence
+// preloadHandler applies for a real Scope.
+func resolvePreloadHandler(registered map[string]PreloadHandlerFunc, kind string) (PreloadHandlerFunc, bool) {
+	if fn, ok := registered[kind]; ok {
+		return fn, true
+	}
+	fn, ok := defaultPreloadHandlers[kind]
+	return fn, ok
+}
+
+// preloadMergeMutex returns the mutex preloadCallback installs on scope
+// before running handlers concurrently, so a handler can guard the point
+// where it writes preloaded results back into the shared parent scope.Value.
+// A handler invoked outside preloadCallback (e.g. directly, in a test) sees
+// no mutex and merges unguarded, same as before concurrent preloading existed.
+func (scope *Scope) preloadMergeMutex() (*sync.Mutex, bool) {
+	if v, ok := scope.Get("gorm:preload_merge_mutex"); ok {
+		if mu, ok := v.(*sync.Mutex); ok {
+			return mu, true
+		}
+	}
+	return nil, false
+}
 
 // preloadCallback used to preload associations
 func preloadCallback(scope *Scope) {
@@ -30,83 +127,242 @@ func preloadCallback(scope *Scope) {
 	}
 
 	var (
-		preloadedMap = map[string]bool{}
+		mu             sync.Mutex
+		preloadedMap   = map[string]bool{}
 		parentQueryMap = map[string]*SqlExpr{}
-		fields       = scope.Fields()
+		fields         = scope.Fields()
+		parallelism    = defaultPreloadParallelism
 	)
 
-	for _, preload := range scope.Search.preload {
-		var (
-			preloadFields = strings.Split(preload.schema, ".")
-			currentScope  = scope
-			currentFields = fields
-		)
-		parentQuery := new(SqlExpr)
-		parentQuery.expr = currentScope.SQL
-		parentQuery.args = currentScope.SQLVars
-		cleanParentSql(parentQuery)
+	if p, ok := scope.Get("gorm:preload_parallelism"); ok {
+		if n, ok := p.(int); ok && n > 0 {
+			parallelism = n
+		}
+	}
 
-		for idx, preloadField := range preloadFields {
-			var currentPreloadConditions []interface{}
+	// Handlers merge their results back into scope.Value -- the struct(s)
+	// every worker's Scope/DB clone still shares -- so guard that merge with
+	// a mutex whenever two independent schemas land in the same wave and run
+	// concurrently.
+	scope.Set("gorm:preload_merge_mutex", &mu)
+
+	// Siblings (e.g. "Orders" and "Author") have no ordering requirement and
+	// can run concurrently; a dependent schema (e.g. "Orders.Items" depending
+	// on "Orders") must wait for its parent to finish first. preloadWaves
+	// groups schemas so that each wave only contains schemas whose
+	// dependencies were satisfied by a previous wave.
+	for _, wave := range preloadWaves(scope.Search.preload) {
+		if scope.HasError() {
+			return
+		}
 
-			if currentScope == nil {
-				continue
+		group, ctx := errgroup.WithContext(context.Background())
+		sem := make(chan struct{}, parallelism)
+
+	waveLoop:
+		for _, preload := range wave {
+			preload := preload
+
+			// Stop handing out new sibling work as soon as the group's
+			// context is cancelled by the first error.
+			select {
+			case <-ctx.Done():
+				break waveLoop
+			case sem <- struct{}{}:
 			}
 
-			// if not preloaded
-			if preloadKey := strings.Join(preloadFields[:idx+1], "."); !preloadedMap[preloadKey] {
-				parentKey := strings.Join(preloadFields[:idx], ".")
-				if _, ok := parentQueryMap[parentKey]; ok {
-					parentQuery = cleanParentSql(parentQueryMap[parentKey])
+			group.Go(func() error {
+				defer func() { <-sem }()
+
+				if err := ctx.Err(); err != nil {
+					return err
 				}
 
+				// Give each worker its own Scope/DB clone so that scope.Err,
+				// SQLVars and callback instance state aren't shared across
+				// goroutines running preloads concurrently.
+				workerScope := scope.NewDB().NewScope(scope.Value)
+				workerScope.SQL = scope.SQL
+				workerScope.SQLVars = append([]interface{}(nil), scope.SQLVars...)
+				return workerScope.runPreload(preload, fields, &mu, preloadedMap, parentQueryMap)
+			})
+		}
 
-				// assign search conditions to last preload
-				if idx == len(preloadFields)-1 {
-					currentPreloadConditions = preload.conditions
-				}
+		if err := group.Wait(); err != nil {
+			scope.Err(err)
+			return
+		}
+	}
+}
+
+// preloadWaves splits preloads into ordered batches where every schema in a
+// batch is independent of the others in that same batch: "A.B" is placed in
+// the batch after the one containing "A", while unrelated schemas land in
+// the earliest batch possible so they can run concurrently.
+func preloadWaves(preloads []searchPreload) [][]searchPreload {
+	schemas := map[string]bool{}
+	for _, preload := range preloads {
+		schemas[preload.schema] = true
+	}
 
-				for _, field := range currentFields {
-					if field.Name != preloadField || field.Relationship == nil {
-						continue
-					}
-
-					switch field.Relationship.Kind {
-					case "has_one":
-						parentQueryMap[preloadKey] = currentScope.handleHasOnePreload(field, currentPreloadConditions, parentQuery)
-					case "has_many":
-						parentQueryMap[preloadKey] = currentScope.handleHasManyPreload(field, currentPreloadConditions, parentQuery)
-					case "belongs_to":
-						parentQueryMap[preloadKey] = currentScope.handleBelongsToPreload(field, currentPreloadConditions, parentQuery)
-					case "many_to_many":
-						parentQueryMap[preloadKey] = currentScope.handleManyToManyPreload(field, currentPreloadConditions, parentQuery)
-					default:
-						scope.Err(errors.New("unsupported relation"))
-					}
-
-					preloadedMap[preloadKey] = true
-					break
+	parentOf := func(schema string) string {
+		parts := strings.Split(schema, ".")
+		for i := len(parts) - 1; i > 0; i-- {
+			if candidate := strings.Join(parts[:i], "."); schemas[candidate] {
+				return candidate
+			}
+		}
+		return ""
+	}
+
+	var (
+		waves     [][]searchPreload
+		done      = map[string]bool{}
+		remaining = preloads
+	)
+
+	for len(remaining) > 0 {
+		var wave, next []searchPreload
+
+		for _, preload := range remaining {
+			if parent := parentOf(preload.schema); parent != "" && !done[parent] {
+				next = append(next, preload)
+			} else {
+				wave = append(wave, preload)
+			}
+		}
+
+		for _, preload := range wave {
+			done[preload.schema] = true
+		}
+
+		waves = append(waves, wave)
+		remaining = next
+	}
+
+	return waves
+}
+
+// runPreload preloads a single schema (e.g. "Orders.Items") against scope,
+// sharing preloadedMap/parentQueryMap with the other schemas in the same
+// preloadCallback run. Callers running this concurrently for sibling
+// schemas must guard preloadedMap/parentQueryMap with mu.
+func (scope *Scope) runPreload(preload searchPreload, rootFields []*Field, mu *sync.Mutex, preloadedMap map[string]bool, parentQueryMap map[string]*SqlExpr) error {
+	var (
+		preloadFields = strings.Split(preload.schema, ".")
+		currentScope  = scope
+		currentFields = rootFields
+	)
+	parentQuery := new(SqlExpr)
+	parentQuery.expr = currentScope.SQL
+	parentQuery.args = currentScope.SQLVars
+	cleanParentSql(parentQuery)
+
+	for idx, preloadField := range preloadFields {
+		var currentPreloadConditions []interface{}
+
+		if currentScope == nil {
+			continue
+		}
+
+		preloadKey := strings.Join(preloadFields[:idx+1], ".")
+
+		mu.Lock()
+		alreadyPreloaded := preloadedMap[preloadKey]
+		if !alreadyPreloaded {
+			parentKey := strings.Join(preloadFields[:idx], ".")
+			if parentSql, ok := parentQueryMap[parentKey]; ok {
+				parentQuery = cleanParentSql(parentSql)
+			}
+		} else if parentSql, ok := parentQueryMap[preloadKey]; ok {
+			parentQuery = parentSql
+		}
+		mu.Unlock()
+
+		// if not preloaded
+		if !alreadyPreloaded {
+			// assign search conditions to last preload
+			if idx == len(preloadFields)-1 {
+				currentPreloadConditions = preload.conditions
+			}
+
+			var handled bool
+			for _, field := range currentFields {
+				if field.Name != preloadField || field.Relationship == nil {
+					continue
 				}
 
-				if !preloadedMap[preloadKey] {
-					scope.Err(fmt.Errorf("can't preload field %s for %s", preloadField, currentScope.GetModelStruct().ModelType))
-					return
+				handler, ok := currentScope.preloadHandler(field.Relationship.Kind)
+				if !ok {
+					return fmt.Errorf("unsupported relation kind %q for field %s", field.Relationship.Kind, field.Name)
 				}
+
+				result := handler(currentScope, field, currentPreloadConditions, parentQuery)
+				parentQuery = result
+				mu.Lock()
+				parentQueryMap[preloadKey] = result
+				preloadedMap[preloadKey] = true
+				mu.Unlock()
+				handled = true
+				break
 			}
 
-			// preload next level
-			if idx < len(preloadFields)-1 {
-				currentScope = currentScope.getColumnAsScope(preloadField)
-				if currentScope != nil {
-					currentFields = currentScope.Fields()
-				}
+			if !handled {
+				return fmt.Errorf("can't preload field %s for %s", preloadField, currentScope.GetModelStruct().ModelType)
+			}
+		}
+
+		// preload next level
+		if idx < len(preloadFields)-1 {
+			currentScope = currentScope.getColumnAsScope(preloadField)
+			if currentScope != nil {
+				currentFields = currentScope.Fields()
 			}
 		}
 	}
+
+	if scope.HasError() {
+		return scope.db.Error
+	}
+	return nil
 }
 
+// defaultAutoPreloadDepth is how many levels of nested relations autoPreload
+// walks when "gorm:auto_preload_depth" hasn't been set on the DB/scope. This
+// matches gorm:auto_preload's pre-existing single-level behavior so upgrading
+// to this release doesn't silently start firing deeper preloads for callers
+// who never asked for them; set "gorm:auto_preload_depth" to opt into more.
+const defaultAutoPreloadDepth = 1
+
 func autoPreload(scope *Scope) {
-	for _, field := range scope.Fields() {
+	depth := defaultAutoPreloadDepth
+	if ap, ok := scope.Get("gorm:auto_preload_depth"); ok {
+		if d, ok := ap.(int); ok {
+			depth = d
+		}
+	}
+
+	autoPreloadNested(scope, scope, "", depth, map[string]bool{})
+}
+
+// autoPreloadNested walks relation fields of currentScope's model, registering
+// a Preload for each one found and recursing into its nested relations up to
+// depth levels. visited is keyed on "ModelType.fieldName" and scoped to the
+// current root-to-node path: it's cloned before recursing into a field, so
+// sibling branches (e.g. Post.Comments[].Author and Post.Replies[].Author)
+// don't mark each other's fields as visited, only true cycles along a single
+// path (e.g. a parent/child back-reference) stop the walk.
+func autoPreloadNested(scope, currentScope *Scope, prefix string, depth int, visited map[string]bool) {
+	if depth <= 0 {
+		if prefix != "" {
+			scope.Log(fmt.Sprintf("gorm: auto preload depth limit reached at %q, not preloading further", prefix))
+		}
+		return
+	}
+
+	modelType := currentScope.GetModelStruct().ModelType
+
+	for _, field := range currentScope.Fields() {
 		if field.Relationship == nil {
 			continue
 		}
@@ -120,27 +376,273 @@ func autoPreload(scope *Scope) {
 			}
 		}
 
-		scope.Search.Preload(field.Name)
+		visitKey := fmt.Sprintf("%v.%v", modelType, field.Name)
+		if visited[visitKey] {
+			scope.Log(fmt.Sprintf("gorm: auto preload cycle detected at %q, not preloading further", prefix+"."+field.Name))
+			continue
+		}
+
+		path := field.Name
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		scope.Search.Preload(path)
+
+		fieldType := field.Struct.Type
+		for fieldType.Kind() == reflect.Ptr || fieldType.Kind() == reflect.Slice {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() != reflect.Struct {
+			continue
+		}
+
+		branchVisited := make(map[string]bool, len(visited)+1)
+		for k, v := range visited {
+			branchVisited[k] = v
+		}
+		branchVisited[visitKey] = true
+
+		nestedScope := currentScope.New(reflect.New(fieldType).Interface())
+		autoPreloadNested(scope, nestedScope, path, depth-1, branchVisited)
 	}
 }
 
-func (scope *Scope) generatePreloadDBWithConditions(conditions []interface{}) (*DB, []interface{}) {
+// defaultPreloadBatchSize is the number of parent key values joined into a
+// single "IN (?)" chunk when "gorm:preload_strategy" is "in_batches", unless
+// "gorm:preload_batch_size" overrides it. Stay comfortably under MySQL's
+// max_allowed_packet and Postgres' 32k bound-parameter limit when tuning it
+// per dialect.
+const defaultPreloadBatchSize = 1000
+
+// preloadStrategy returns "in_batches" or "subquery" (the default), set via
+// db.Set("gorm:preload_strategy", ...).
+func (scope *Scope) preloadStrategy() string {
+	if strategy, ok := scope.Get("gorm:preload_strategy"); ok {
+		if s, ok := strategy.(string); ok && s == "in_batches" {
+			return "in_batches"
+		}
+	}
+	return "subquery"
+}
+
+func (scope *Scope) preloadBatchSize() int {
+	if size, ok := scope.Get("gorm:preload_batch_size"); ok {
+		if n, ok := size.(int); ok && n > 0 {
+			return n
+		}
+	}
+	return defaultPreloadBatchSize
+}
+
+// preloadKeyValues gathers the distinct values of fieldNames across every row
+// held by scope.Value, for use as the right-hand side of an "IN (?)" chunk.
+func preloadKeyValues(scope *Scope, fieldNames []string) []interface{} {
+	var (
+		indirectScopeValue = scope.IndirectValue()
+		seen                = map[string]bool{}
+		keys                []interface{}
+	)
+
+	collect := func(value reflect.Value) {
+		fieldValues := getValueFromFields(value, fieldNames)
+		key := toString(fieldValues)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		if len(fieldValues) == 1 {
+			keys = append(keys, fieldValues[0])
+		} else {
+			keys = append(keys, fieldValues)
+		}
+	}
+
+	if indirectScopeValue.Kind() == reflect.Slice {
+		for i := 0; i < indirectScopeValue.Len(); i++ {
+			collect(indirect(indirectScopeValue.Index(i)))
+		}
+	} else if indirectScopeValue.IsValid() {
+		collect(indirectScopeValue)
+	}
+
+	return keys
+}
+
+// chunkPreloadKeys splits keys into groups of at most size.
+func chunkPreloadKeys(keys []interface{}, size int) [][]interface{} {
+	if size <= 0 {
+		size = defaultPreloadBatchSize
+	}
+
+	var chunks [][]interface{}
+	for len(keys) > size {
+		keys, chunks = keys[size:], append(chunks, keys[0:size:size])
+	}
+	if len(keys) > 0 {
+		chunks = append(chunks, keys)
+	}
+	return chunks
+}
+
+// findPreloadInBatches materializes the parent key values (sourceFieldNames,
+// read from scope.Value) in Go and issues one "targetDBNames IN (?)" query
+// per chunk instead of embedding parentQuery as a correlated subquery. The
+// per-chunk results are unioned into a single slice, and their queries are
+// joined with SQL UNION so deeper nested preloads can still correlate
+// against them the same way the subquery strategy does.
+func (scope *Scope) findPreloadInBatches(preloadDB *DB, elemType reflect.Type, sourceFieldNames, targetDBNames []string, relation *Relationship, preloadConditions []interface{}) (reflect.Value, *SqlExpr) {
+	var (
+		combined  reflect.Value
+		unionExpr []string
+		unionArgs []interface{}
+	)
+
+	keys := preloadKeyValues(scope, sourceFieldNames)
+	for _, chunk := range chunkPreloadKeys(keys, scope.preloadBatchSize()) {
+		query := fmt.Sprintf("%v IN (?)", toQueryCondition(scope, targetDBNames))
+		values := []interface{}{chunk}
+		if relation.PolymorphicType != "" {
+			query += fmt.Sprintf(" AND %v = ?", scope.Quote(relation.PolymorphicDBName))
+			values = append(values, relation.PolymorphicValue)
+		}
+
+		chunkResults := makeSlice(elemType)
+		chunkQuery := preloadDB.Model(chunkResults).Where(query, values...)
+		scope.Err(chunkQuery.Find(chunkResults, preloadConditions...).Error)
+
+		chunkResultsValue := indirect(reflect.ValueOf(chunkResults))
+		if !combined.IsValid() {
+			combined = reflect.MakeSlice(chunkResultsValue.Type(), 0, chunkResultsValue.Len())
+		}
+		combined = reflect.AppendSlice(combined, chunkResultsValue)
+
+		chunkExpr := chunkQuery.QueryExpr()
+		unionExpr = append(unionExpr, chunkExpr.expr)
+		unionArgs = append(unionArgs, chunkExpr.args...)
+	}
+
+	if !combined.IsValid() {
+		combined = reflect.MakeSlice(reflect.SliceOf(elemType), 0, 0)
+	}
+
+	return combined, &SqlExpr{expr: strings.Join(unionExpr, " UNION "), args: unionArgs}
+}
+
+// preloadSelect is the condition type returned by PreloadSelect.
+type preloadSelect struct {
+	columns []string
+}
+
+// PreloadSelect limits a preloaded association to the given columns, e.g.
+// Preload("Orders", PreloadSelect("id", "total")). The association's foreign
+// key columns are appended automatically so the in-memory join in
+// handleHasOnePreload/handleHasManyPreload/handleBelongsToPreload still finds
+// a match even if the caller didn't think to ask for them.
+func PreloadSelect(columns ...string) interface{} {
+	return &preloadSelect{columns: columns}
+}
+
+// generatePreloadDBWithConditions applies conditions to a fresh DB derived
+// from scope, splitting out plain Find conditions from scope functions and
+// PreloadSelect column lists. requiredDBNames are the foreign key columns the
+// calling handler's in-memory join needs present on the preloaded rows: a
+// PreloadSelect is widened to include any that are missing, while a raw
+// Select condition (an opaque SQL string gorm can't safely rewrite) that
+// omits one is reported as an error instead of silently returning empty
+// associations.
+func (scope *Scope) generatePreloadDBWithConditions(conditions []interface{}, requiredDBNames []string) (*DB, []interface{}) {
 	var (
 		preloadDB         = scope.NewDB()
 		preloadConditions []interface{}
+		selectColumns     []string
 	)
 
 	for _, condition := range conditions {
-		if scopes, ok := condition.(func(*DB) *DB); ok {
-			preloadDB = scopes(preloadDB)
-		} else {
+		switch value := condition.(type) {
+		case func(*DB) *DB:
+			preloadDB = value(preloadDB)
+		case *preloadSelect:
+			selectColumns = append(selectColumns, value.columns...)
+		default:
 			preloadConditions = append(preloadConditions, condition)
 		}
 	}
 
+	if len(selectColumns) > 0 {
+		for _, required := range requiredDBNames {
+			if !containsColumnFold(selectColumns, required) {
+				selectColumns = append(selectColumns, required)
+			}
+		}
+		preloadDB = preloadDB.Select(strings.Join(selectColumns, ", "))
+	}
+
+	if len(requiredDBNames) > 0 && len(preloadDB.search.selects) > 0 {
+		if missing := missingSelectColumns(preloadDB, requiredDBNames); len(missing) > 0 {
+			scope.Err(fmt.Errorf("preload select for %v is missing required foreign key column(s) %v", scope.GetModelStruct().ModelType, missing))
+		}
+	}
+
 	return preloadDB, preloadConditions
 }
 
+func containsColumnFold(columns []string, target string) bool {
+	for _, column := range columns {
+		if strings.EqualFold(column, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// missingSelectColumns reports which of requiredDBNames aren't present in
+// preloadDB's explicit Select query. It parses the actual comma-separated
+// column list (dropping table qualifiers/backticks) rather than doing a
+// substring match against the query, so a short column name like "id" can't
+// false-positive match as "selected" just because it appears inside another
+// token.
+func missingSelectColumns(preloadDB *DB, requiredDBNames []string) []string {
+	rawQuery, ok := preloadDB.search.selects["query"]
+	if !ok {
+		return nil
+	}
+
+	raw, ok := rawQuery.(string)
+	if !ok || strings.TrimSpace(raw) == "*" {
+		return nil
+	}
+
+	selected := selectedColumnNames(raw)
+
+	var missing []string
+	for _, name := range requiredDBNames {
+		if !containsColumnFold(selected, name) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// selectedColumnNames splits a raw "SELECT ..." column list (e.g.
+// "orders.id, `total` AS amount") into bare column names ("id", "total").
+func selectedColumnNames(raw string) []string {
+	var columns []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.Trim(strings.TrimSpace(part), "`\"")
+		if part == "" {
+			continue
+		}
+		if idx := strings.LastIndex(part, "."); idx >= 0 {
+			part = part[idx+1:]
+		}
+		if fields := strings.Fields(part); len(fields) > 0 {
+			part = strings.Trim(fields[0], "`\"")
+		}
+		columns = append(columns, part)
+	}
+	return columns
+}
+
 // handleHasOnePreload used to preload has one associations
 func (scope *Scope) handleHasOnePreload(field *Field, conditions []interface{}, parentQuery *SqlExpr) *SqlExpr  {
 	relation := field.Relationship
@@ -156,28 +658,42 @@ func (scope *Scope) handleHasOnePreload(field *Field, conditions []interface{},
 	}
 
 	// preload conditions
-	preloadDB, preloadConditions := scope.generatePreloadDBWithConditions(conditions)
+	preloadDB, preloadConditions := scope.generatePreloadDBWithConditions(conditions, relation.ForeignDBNames)
 
-	subQuerySQL := parentQuery.expr
-	subQuerySQL = "SELECT " + toQueryCondition(scope, relation.AssociationForeignDBNames) + " FROM (" + subQuerySQL + ") " + scope.Quote("HO_" + field.DBName)
+	var (
+		resultsValue    reflect.Value
+		nextParentQuery *SqlExpr
+	)
 
-	// find relations
-	query := fmt.Sprintf("%v IN (%v)", toQueryCondition(scope, relation.ForeignDBNames), subQuerySQL)
-	values := parentQuery.args
-	if relation.PolymorphicType != "" {
-		query += fmt.Sprintf(" AND %v = ?", scope.Quote(relation.PolymorphicDBName))
-		values = append(values, relation.PolymorphicValue)
-	}
+	if scope.preloadStrategy() == "in_batches" {
+		resultsValue, nextParentQuery = scope.findPreloadInBatches(preloadDB, field.Struct.Type, relation.AssociationForeignFieldNames, relation.ForeignDBNames, relation, preloadConditions)
+	} else {
+		subQuerySQL := parentQuery.expr
+		subQuerySQL = "SELECT " + toQueryCondition(scope, relation.AssociationForeignDBNames) + " FROM (" + subQuerySQL + ") " + scope.Quote("HO_" + field.DBName)
+
+		// find relations
+		query := fmt.Sprintf("%v IN (%v)", toQueryCondition(scope, relation.ForeignDBNames), subQuerySQL)
+		values := parentQuery.args
+		if relation.PolymorphicType != "" {
+			query += fmt.Sprintf(" AND %v = ?", scope.Quote(relation.PolymorphicDBName))
+			values = append(values, relation.PolymorphicValue)
+		}
 
-	results := makeSlice(field.Struct.Type)
-	preloadQuery := preloadDB.Model(results).Where(query, values...)
-	scope.Err(preloadQuery.Find(results, preloadConditions...).Error)
+		results := makeSlice(field.Struct.Type)
+		preloadQuery := preloadDB.Model(results).Where(query, values...)
+		scope.Err(preloadQuery.Find(results, preloadConditions...).Error)
+
+		resultsValue = indirect(reflect.ValueOf(results))
+		nextParentQuery = preloadQuery.QueryExpr()
+	}
 
 	// assign find results
-	var (
-		resultsValue       = indirect(reflect.ValueOf(results))
-		indirectScopeValue = scope.IndirectValue()
-	)
+	if mu, ok := scope.preloadMergeMutex(); ok {
+		mu.Lock()
+		defer mu.Unlock()
+	}
+
+	indirectScopeValue := scope.IndirectValue()
 
 	if indirectScopeValue.Kind() == reflect.Slice {
 		foreignValuesToResults := make(map[string]reflect.Value)
@@ -199,7 +715,7 @@ func (scope *Scope) handleHasOnePreload(field *Field, conditions []interface{},
 			scope.Err(field.Set(result))
 		}
 	}
-	return preloadQuery.QueryExpr()
+	return nextParentQuery
 }
 
 // handleHasManyPreload used to preload has many associations
@@ -217,28 +733,42 @@ func (scope *Scope) handleHasManyPreload(field *Field, conditions []interface{},
 	}
 
 	// preload conditions
-	preloadDB, preloadConditions := scope.generatePreloadDBWithConditions(conditions)
+	preloadDB, preloadConditions := scope.generatePreloadDBWithConditions(conditions, relation.ForeignDBNames)
 
-	subQuerySQL := parentSql.expr
-	subQuerySQL = "SELECT " + toQueryCondition(scope, relation.AssociationForeignDBNames) + " FROM (" + subQuerySQL + ") " + scope.Quote("HM_" + field.DBName)
+	var (
+		resultsValue    reflect.Value
+		nextParentQuery *SqlExpr
+	)
 
-	// find relations
-	query := fmt.Sprintf("%v IN (%v)", toQueryCondition(scope, relation.ForeignDBNames), subQuerySQL)
-	values := parentSql.args
-	if relation.PolymorphicType != "" {
-		query += fmt.Sprintf(" AND %v = ?", scope.Quote(relation.PolymorphicDBName))
-		values = append(values, relation.PolymorphicValue)
-	}
+	if scope.preloadStrategy() == "in_batches" {
+		resultsValue, nextParentQuery = scope.findPreloadInBatches(preloadDB, field.Struct.Type, relation.AssociationForeignFieldNames, relation.ForeignDBNames, relation, preloadConditions)
+	} else {
+		subQuerySQL := parentSql.expr
+		subQuerySQL = "SELECT " + toQueryCondition(scope, relation.AssociationForeignDBNames) + " FROM (" + subQuerySQL + ") " + scope.Quote("HM_" + field.DBName)
+
+		// find relations
+		query := fmt.Sprintf("%v IN (%v)", toQueryCondition(scope, relation.ForeignDBNames), subQuerySQL)
+		values := parentSql.args
+		if relation.PolymorphicType != "" {
+			query += fmt.Sprintf(" AND %v = ?", scope.Quote(relation.PolymorphicDBName))
+			values = append(values, relation.PolymorphicValue)
+		}
+
+		results := makeSlice(field.Struct.Type)
+		preloadQuery := preloadDB.Model(results).Where(query, values...)
+		scope.Err(preloadQuery.Find(results, preloadConditions...).Error)
 
-	results := makeSlice(field.Struct.Type)
-	preloadQuery := preloadDB.Model(results).Where(query, values...)
-	scope.Err(preloadQuery.Find(results, preloadConditions...).Error)
+		resultsValue = indirect(reflect.ValueOf(results))
+		nextParentQuery = preloadQuery.QueryExpr()
+	}
 
 	// assign find results
-	var (
-		resultsValue       = indirect(reflect.ValueOf(results))
-		indirectScopeValue = scope.IndirectValue()
-	)
+	if mu, ok := scope.preloadMergeMutex(); ok {
+		mu.Lock()
+		defer mu.Unlock()
+	}
+
+	indirectScopeValue := scope.IndirectValue()
 
 	if indirectScopeValue.Kind() == reflect.Slice {
 		preloadMap := make(map[string][]reflect.Value)
@@ -261,7 +791,7 @@ func (scope *Scope) handleHasManyPreload(field *Field, conditions []interface{},
 	} else {
 		scope.Err(field.Set(resultsValue))
 	}
-	return preloadQuery.QueryExpr()
+	return nextParentQuery
 }
 
 // handleBelongsToPreload used to preload belongs to associations
@@ -269,7 +799,7 @@ func (scope *Scope) handleBelongsToPreload(field *Field, conditions []interface{
 	relation := field.Relationship
 
 	// preload conditions
-	preloadDB, preloadConditions := scope.generatePreloadDBWithConditions(conditions)
+	preloadDB, preloadConditions := scope.generatePreloadDBWithConditions(conditions, relation.AssociationForeignDBNames)
 
 	if scope.Value == nil{
 		return parentSQL
@@ -280,19 +810,33 @@ func (scope *Scope) handleBelongsToPreload(field *Field, conditions []interface{
 		return parentSQL
 	}
 
-	subQuerySQL := parentSQL.expr
-	subQuerySQL = "SELECT " + toQueryCondition(scope, relation.ForeignDBNames) + " FROM (" + subQuerySQL + ") " + scope.Quote("BT_" + field.DBName)
+	var (
+		resultsValue    reflect.Value
+		nextParentQuery *SqlExpr
+	)
 
-	// find relations
-	results := makeSlice(field.Struct.Type)
-	preloadQuery := preloadDB.Model(results).Where(fmt.Sprintf("%v IN (%v)", toQueryCondition(scope, relation.AssociationForeignDBNames), subQuerySQL),parentSQL.args)
-	scope.Err(preloadQuery.Find(results, preloadConditions...).Error)
+	if scope.preloadStrategy() == "in_batches" {
+		resultsValue, nextParentQuery = scope.findPreloadInBatches(preloadDB, field.Struct.Type, relation.ForeignFieldNames, relation.AssociationForeignDBNames, relation, preloadConditions)
+	} else {
+		subQuerySQL := parentSQL.expr
+		subQuerySQL = "SELECT " + toQueryCondition(scope, relation.ForeignDBNames) + " FROM (" + subQuerySQL + ") " + scope.Quote("BT_" + field.DBName)
+
+		// find relations
+		results := makeSlice(field.Struct.Type)
+		preloadQuery := preloadDB.Model(results).Where(fmt.Sprintf("%v IN (%v)", toQueryCondition(scope, relation.AssociationForeignDBNames), subQuerySQL), parentSQL.args)
+		scope.Err(preloadQuery.Find(results, preloadConditions...).Error)
+
+		resultsValue = indirect(reflect.ValueOf(results))
+		nextParentQuery = preloadQuery.QueryExpr()
+	}
 
 	// assign find results
-	var (
-		resultsValue       = indirect(reflect.ValueOf(results))
-		indirectScopeValue = scope.IndirectValue()
-	)
+	if mu, ok := scope.preloadMergeMutex(); ok {
+		mu.Lock()
+		defer mu.Unlock()
+	}
+
+	indirectScopeValue := scope.IndirectValue()
 
 	foreignFieldToObjects := make(map[string][]*reflect.Value)
 	if indirectScopeValue.Kind() == reflect.Slice {
@@ -316,7 +860,7 @@ func (scope *Scope) handleBelongsToPreload(field *Field, conditions []interface{
 			scope.Err(field.Set(result))
 		}
 	}
-	return preloadQuery.QueryExpr()
+	return nextParentQuery
 }
 
 // handleManyToManyPreload used to preload many to many associations
@@ -346,11 +890,19 @@ func (scope *Scope) handleManyToManyPreload(field *Field, conditions []interface
 		sourceKeys = append(sourceKeys, key.DBName)
 	}
 
-	// preload conditions
-	preloadDB, preloadConditions := scope.generatePreloadDBWithConditions(conditions)
-
 	// generate query with join table
 	newScope := scope.New(reflect.New(fieldType).Interface())
+
+	// A PreloadSelect must keep the association's own primary key(s): they're
+	// what every later Association()/Save() call on the preloaded rows keys
+	// off of, so losing them would silently break far more than this preload.
+	var requiredDBNames []string
+	for _, primaryField := range newScope.PrimaryFields() {
+		requiredDBNames = append(requiredDBNames, primaryField.DBName)
+	}
+
+	// preload conditions
+	preloadDB, preloadConditions := scope.generatePreloadDBWithConditions(conditions, requiredDBNames)
 	preloadDB = preloadDB.Table(newScope.TableName()).Model(newScope.Value)
 
 	if len(preloadDB.search.selects) == 0 {
@@ -413,6 +965,11 @@ func (scope *Scope) handleManyToManyPreload(field *Field, conditions []interface
 	}
 
 	// assign find results
+	if mu, ok := scope.preloadMergeMutex(); ok {
+		mu.Lock()
+		defer mu.Unlock()
+	}
+
 	var (
 		indirectScopeValue = scope.IndirectValue()
 		fieldsSourceMap    = map[string][]reflect.Value{}
@@ -0,0 +1,164 @@
+package gorm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPreloadWavesOrdersDependentsAfterTheirParent(t *testing.T) {
+	preloads := []searchPreload{
+		{schema: "Orders.Items"},
+		{schema: "Author"},
+		{schema: "Orders"},
+	}
+
+	waves := preloadWaves(preloads)
+
+	if len(waves) != 2 {
+		t.Fatalf("expected 2 waves, got %d: %+v", len(waves), waves)
+	}
+
+	firstWave := map[string]bool{}
+	for _, p := range waves[0] {
+		firstWave[p.schema] = true
+	}
+	if !firstWave["Orders"] || !firstWave["Author"] {
+		t.Fatalf("expected Orders and Author as independent siblings in the first wave, got %+v", waves[0])
+	}
+	if firstWave["Orders.Items"] {
+		t.Fatalf("Orders.Items must not run before its parent Orders, first wave was %+v", waves[0])
+	}
+
+	if len(waves[1]) != 1 || waves[1][0].schema != "Orders.Items" {
+		t.Fatalf("expected Orders.Items alone in the second wave, got %+v", waves[1])
+	}
+}
+
+func TestPreloadWavesTreatsUnrelatedSchemasAsIndependent(t *testing.T) {
+	preloads := []searchPreload{
+		{schema: "A"},
+		{schema: "B"},
+		{schema: "C"},
+	}
+
+	waves := preloadWaves(preloads)
+
+	if len(waves) != 1 {
+		t.Fatalf("expected every sibling schema in a single wave, got %d waves: %+v", len(waves), waves)
+	}
+}
+
+func TestChunkPreloadKeysSplitsAtTheConfiguredBoundary(t *testing.T) {
+	keys := make([]interface{}, 7)
+	for i := range keys {
+		keys[i] = i
+	}
+
+	chunks := chunkPreloadKeys(keys, 3)
+
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks of size 3/3/1, got %d: %+v", len(chunks), chunks)
+	}
+	if len(chunks[0]) != 3 || len(chunks[1]) != 3 || len(chunks[2]) != 1 {
+		t.Fatalf("unexpected chunk sizes: %+v", chunks)
+	}
+
+	var flattened []interface{}
+	for _, chunk := range chunks {
+		flattened = append(flattened, chunk...)
+	}
+	if !reflect.DeepEqual(flattened, keys) {
+		t.Fatalf("chunking must not drop or reorder keys, got %+v want %+v", flattened, keys)
+	}
+}
+
+func TestChunkPreloadKeysFallsBackToDefaultSizeWhenUnset(t *testing.T) {
+	keys := make([]interface{}, defaultPreloadBatchSize+1)
+	for i := range keys {
+		keys[i] = i
+	}
+
+	chunks := chunkPreloadKeys(keys, 0)
+
+	if len(chunks) != 2 || len(chunks[0]) != defaultPreloadBatchSize || len(chunks[1]) != 1 {
+		t.Fatalf("expected a %d/1 split using the default batch size, got sizes %d/%d", defaultPreloadBatchSize, len(chunks[0]), len(chunks[1]))
+	}
+}
+
+func TestSelectedColumnNamesStripsQualifiersAndQuoting(t *testing.T) {
+	got := selectedColumnNames("orders.id, `total` AS amount, \"status\"")
+	want := []string{"id", "total", "status"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("selectedColumnNames() = %+v, want %+v", got, want)
+	}
+}
+
+func TestContainsColumnFoldDoesNotFalsePositiveOnSubstrings(t *testing.T) {
+	columns := []string{"valid", "total"}
+
+	if containsColumnFold(columns, "id") {
+		t.Fatalf("containsColumnFold must not match \"id\" as a substring of \"valid\"")
+	}
+	if !containsColumnFold(columns, "ID") {
+		t.Fatalf("containsColumnFold must match case-insensitively")
+	}
+}
+
+func TestResolvePreloadHandlerDispatchesToACustomKind(t *testing.T) {
+	called := false
+	custom := PreloadHandlerFunc(func(scope *Scope, field *Field, conditions []interface{}, parent *SqlExpr) *SqlExpr {
+		called = true
+		return parent
+	})
+
+	fn, ok := resolvePreloadHandler(mergePreloadHandlers(nil, "polymorphic_any", custom), "polymorphic_any")
+	if !ok {
+		t.Fatalf("expected a handler to be found for the registered custom kind")
+	}
+	fn(nil, nil, nil, nil)
+	if !called {
+		t.Fatalf("resolvePreloadHandler returned a handler other than the registered one")
+	}
+
+	if _, ok := resolvePreloadHandler(nil, "polymorphic_any"); ok {
+		t.Fatalf("expected no handler for an unregistered kind with no registrations at all")
+	}
+}
+
+func TestResolvePreloadHandlerRegisteredOverridesBuiltin(t *testing.T) {
+	if _, ok := resolvePreloadHandler(nil, "has_one"); !ok {
+		t.Fatalf("expected the built-in has_one handler when nothing is registered")
+	}
+
+	called := false
+	override := PreloadHandlerFunc(func(scope *Scope, field *Field, conditions []interface{}, parent *SqlExpr) *SqlExpr {
+		called = true
+		return parent
+	})
+
+	fn, ok := resolvePreloadHandler(mergePreloadHandlers(nil, "has_one", override), "has_one")
+	if !ok {
+		t.Fatalf("expected a handler for has_one")
+	}
+	fn(nil, nil, nil, nil)
+	if !called {
+		t.Fatalf("registering \"has_one\" must override the built-in handler, not just add alongside it")
+	}
+}
+
+func TestMergePreloadHandlersDoesNotMutateTheExistingMap(t *testing.T) {
+	original := mergePreloadHandlers(nil, "has_one", defaultPreloadHandlers["has_one"])
+
+	merged := mergePreloadHandlers(original, "has_many", defaultPreloadHandlers["has_many"])
+
+	if _, ok := original["has_many"]; ok {
+		t.Fatalf("mergePreloadHandlers must not mutate its existing argument")
+	}
+	if _, ok := merged["has_one"]; !ok {
+		t.Fatalf("merged map must still contain entries carried over from existing")
+	}
+	if _, ok := merged["has_many"]; !ok {
+		t.Fatalf("merged map must contain the newly added kind")
+	}
+}